@@ -0,0 +1,148 @@
+package goxlsx
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ColumnNameToNumber converts a column letter sequence such as "A", "Z",
+// "AA" or "AAA" to its 1-based column number. Lowercase letters are
+// accepted and treated the same as uppercase.
+func ColumnNameToNumber(name string) (int, error) {
+	if name == "" {
+		return 0, fmt.Errorf("empty column name")
+	}
+	n := 0
+	for _, r := range strings.ToUpper(name) {
+		if r < 'A' || r > 'Z' {
+			return 0, fmt.Errorf("invalid column name %q", name)
+		}
+		n = n*26 + int(r-'A') + 1
+	}
+	return n, nil
+}
+
+// ColumnNumberToName converts a 1-based column number to its letter
+// sequence, e.g. 1 -> "A", 26 -> "Z", 27 -> "AA".
+func ColumnNumberToName(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	var b []byte
+	for n > 0 {
+		n--
+		b = append([]byte{byte('A' + n%26)}, b...)
+		n /= 26
+	}
+	return string(b)
+}
+
+var cellNameRegexp = regexp.MustCompile(`^([A-Za-z]+)([0-9]+)$`)
+
+// SplitCellName splits a cell reference such as "AC101" into its column
+// letters ("AC") and row digits ("101").
+func SplitCellName(name string) (column, row string, err error) {
+	m := cellNameRegexp.FindStringSubmatch(name)
+	if m == nil {
+		return "", "", fmt.Errorf("invalid cell name %q", name)
+	}
+	return m[1], m[2], nil
+}
+
+// CellNameToCoordinates converts a cell reference such as "AC101" to its
+// 1-based column and row numbers.
+func CellNameToCoordinates(name string) (column, row int, err error) {
+	colName, rowName, err := SplitCellName(name)
+	if err != nil {
+		return 0, 0, err
+	}
+	column, err = ColumnNameToNumber(colName)
+	if err != nil {
+		return 0, 0, err
+	}
+	row, err = strconv.Atoi(rowName)
+	if err != nil {
+		return 0, 0, err
+	}
+	return column, row, nil
+}
+
+// CoordinatesToCellName converts a 1-based column and row number to a cell
+// reference such as "AC101".
+func CoordinatesToCellName(column, row int) string {
+	return fmt.Sprintf("%s%d", ColumnNumberToName(column), row)
+}
+
+// ParseRange parses a range reference such as "A1:C10" into a CellRange.
+// The two corners may be given in any order. A lone cell reference with no
+// ":", such as "A1", is treated as a 1x1 range, matching how Excel writes
+// <dimension ref="A1"/> for a single-cell or empty used range.
+func ParseRange(ref string) (CellRange, error) {
+	parts := strings.Split(ref, ":")
+	if len(parts) == 1 {
+		col, row, err := CellNameToCoordinates(parts[0])
+		if err != nil {
+			return CellRange{}, err
+		}
+		return CellRange{MinCol: col, MinRow: row, MaxCol: col, MaxRow: row}, nil
+	}
+	if len(parts) != 2 {
+		return CellRange{}, fmt.Errorf("invalid range %q", ref)
+	}
+	minCol, minRow, err := CellNameToCoordinates(parts[0])
+	if err != nil {
+		return CellRange{}, err
+	}
+	maxCol, maxRow, err := CellNameToCoordinates(parts[1])
+	if err != nil {
+		return CellRange{}, err
+	}
+	if minCol > maxCol {
+		minCol, maxCol = maxCol, minCol
+	}
+	if minRow > maxRow {
+		minRow, maxRow = maxRow, minRow
+	}
+	return CellRange{MinCol: minCol, MinRow: minRow, MaxCol: maxCol, MaxRow: maxRow}, nil
+}
+
+// CellRangeIterator walks the cells of a CellRange in row-major order.
+type CellRangeIterator struct {
+	ws       *Worksheet
+	rng      CellRange
+	col, row int
+}
+
+// CellsInRange returns an iterator over every cell in rng, in row-major
+// order (left to right, then top to bottom).
+func (ws *Worksheet) CellsInRange(rng CellRange) *CellRangeIterator {
+	return &CellRangeIterator{ws: ws, rng: rng, col: rng.MinCol - 1, row: rng.MinRow}
+}
+
+// Next advances the iterator to the next cell and reports whether one
+// exists.
+func (it *CellRangeIterator) Next() bool {
+	it.col++
+	if it.col > it.rng.MaxCol {
+		it.col = it.rng.MinCol
+		it.row++
+	}
+	return it.row <= it.rng.MaxRow
+}
+
+// Column returns the current cell's 1-based column number.
+func (it *CellRangeIterator) Column() int {
+	return it.col
+}
+
+// Row returns the current cell's 1-based row number.
+func (it *CellRangeIterator) Row() int {
+	return it.row
+}
+
+// Value returns the current cell's value, as Worksheet.Cell would.
+func (it *CellRangeIterator) Value() string {
+	return it.ws.Cell(it.col, it.row)
+}
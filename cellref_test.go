@@ -0,0 +1,105 @@
+package goxlsx
+
+import "testing"
+
+func TestColumnNameNumberRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		num  int
+	}{
+		{"A", 1},
+		{"Z", 26},
+		{"AA", 27},
+		{"AZ", 52},
+		{"ZZ", 702},
+		{"AAA", 703},
+	}
+	for _, c := range cases {
+		if got, err := ColumnNameToNumber(c.name); err != nil || got != c.num {
+			t.Errorf("ColumnNameToNumber(%q) = (%d, %v), want %d", c.name, got, err, c.num)
+		}
+		if got := ColumnNumberToName(c.num); got != c.name {
+			t.Errorf("ColumnNumberToName(%d) = %q, want %q", c.num, got, c.name)
+		}
+	}
+
+	if got, err := ColumnNameToNumber("aa"); err != nil || got != 27 {
+		t.Errorf("ColumnNameToNumber(lowercase) = (%d, %v), want (27, nil)", got, err)
+	}
+	if _, err := ColumnNameToNumber("A1"); err == nil {
+		t.Error("ColumnNameToNumber(\"A1\") should error on digits")
+	}
+}
+
+func TestCellNameToCoordinates(t *testing.T) {
+	col, row, err := CellNameToCoordinates("AC101")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col != 29 || row != 101 {
+		t.Errorf("CellNameToCoordinates(AC101) = (%d, %d), want (29, 101)", col, row)
+	}
+	if got := CoordinatesToCellName(29, 101); got != "AC101" {
+		t.Errorf("CoordinatesToCellName(29, 101) = %q, want %q", got, "AC101")
+	}
+	if _, _, err := CellNameToCoordinates("101"); err == nil {
+		t.Error("CellNameToCoordinates(\"101\") should error without a column")
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	rng, err := ParseRange("A1:C10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := CellRange{MinCol: 1, MinRow: 1, MaxCol: 3, MaxRow: 10}
+	if rng != want {
+		t.Errorf("ParseRange(A1:C10) = %+v, want %+v", rng, want)
+	}
+}
+
+func TestParseRangeLoneCell(t *testing.T) {
+	rng, err := ParseRange("A1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := CellRange{MinCol: 1, MinRow: 1, MaxCol: 1, MaxRow: 1}
+	if rng != want {
+		t.Errorf("ParseRange(A1) = %+v, want %+v", rng, want)
+	}
+}
+
+func TestCellsInRange(t *testing.T) {
+	const xml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <dimension ref="A1:B2"/>
+  <sheetData>
+    <row r="1"><c r="A1"><v>1</v></c><c r="B1"><v>2</v></c></row>
+    <row r="2"><c r="A2"><v>3</v></c><c r="B2"><v>4</v></c></row>
+  </sheetData>
+</worksheet>`
+	s := &Spreadsheet{}
+	ws, err := s.readWorksheet([]byte(xml))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rng, err := ParseRange("A1:B2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := ws.CellsInRange(rng)
+	var got []string
+	for it.Next() {
+		got = append(got, it.Value())
+	}
+	want := []string{"1", "2", "3", "4"}
+	if len(got) != len(want) {
+		t.Fatalf("CellsInRange yielded %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("CellsInRange()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package goxlsx
+
+import "testing"
+
+const testWorksheetXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <dimension ref="A1:B3"/>
+  <sheetData>
+    <row r="1">
+      <c r="A1"><v>1</v></c>
+      <c r="B1" t="shared"><f t="shared" ref="B1:B3" si="0">A1*2</f><v>2</v></c>
+    </row>
+    <row r="2">
+      <c r="A2"><v>3</v></c>
+      <c r="B2" t="shared"><f t="shared" si="0"/><v>6</v></c>
+    </row>
+    <row r="3">
+      <c r="A3"/>
+      <c r="B3" t="shared"><f t="shared" si="0"/><v>0</v></c>
+    </row>
+  </sheetData>
+  <mergeCells count="1">
+    <mergeCell ref="A1:A3"/>
+  </mergeCells>
+</worksheet>`
+
+func TestReadWorksheetMergedCellsAndSharedFormulas(t *testing.T) {
+	s := &Spreadsheet{}
+	ws, err := s.readWorksheet([]byte(testWorksheetXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged := ws.MergedCells()
+	if len(merged) != 1 {
+		t.Fatalf("MergedCells() = %v, want 1 range", merged)
+	}
+	if merged[0] != (CellRange{MinCol: 1, MinRow: 1, MaxCol: 1, MaxRow: 3}) {
+		t.Errorf("MergedCells()[0] = %+v", merged[0])
+	}
+
+	if got := ws.Cell(1, 3); got != "" {
+		t.Errorf("Cell(1,3) = %q, want empty (no ExpandMergedCells)", got)
+	}
+	if got := ws.Cell(1, 3, ExpandMergedCells()); got != "1" {
+		t.Errorf("Cell(1,3, ExpandMergedCells()) = %q, want %q", got, "1")
+	}
+
+	if got := ws.Formula(2, 1); got != "A1*2" {
+		t.Errorf("Formula(2,1) = %q, want %q", got, "A1*2")
+	}
+	if got := ws.Formula(2, 3); got != "A1*2" {
+		t.Errorf("Formula(2,3) = %q, want the master formula %q", got, "A1*2")
+	}
+}
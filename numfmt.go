@@ -0,0 +1,279 @@
+package goxlsx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// builtinNumFmts maps the builtin number format ids defined by ECMA-376
+// 18.8.30 to their format codes. Ids 164 and above are always custom and
+// come from styles.xml instead.
+var builtinNumFmts = map[int]string{
+	0:  "General",
+	1:  "0",
+	2:  "0.00",
+	3:  "#,##0",
+	4:  "#,##0.00",
+	9:  "0%",
+	10: "0.00%",
+	11: "0.00E+00",
+	12: "# ?/?",
+	13: "# ??/??",
+	14: "mm-dd-yy",
+	15: "d-mmm-yy",
+	16: "d-mmm",
+	17: "mmm-yy",
+	18: "h:mm AM/PM",
+	19: "h:mm:ss AM/PM",
+	20: "h:mm",
+	21: "h:mm:ss",
+	22: "m/d/yy h:mm",
+	37: "#,##0 ;(#,##0)",
+	38: "#,##0 ;[Red](#,##0)",
+	39: "#,##0.00;(#,##0.00)",
+	40: "#,##0.00;[Red](#,##0.00)",
+	45: "mm:ss",
+	46: "[h]:mm:ss",
+	47: "mmss.0",
+	48: "##0.0E+0",
+	49: "@",
+}
+
+// excelEpoch1900 and excelEpoch1904 are the "day zero" for Excel's two date
+// systems. The 1900 epoch is 1899-12-30, two days before 1900-01-01: one
+// because serial 1 is 1900-01-01, and one more to account for Lotus 1-2-3's
+// (and Excel's) erroneous treatment of 1900 as a leap year.
+var (
+	excelEpoch1900 = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	excelEpoch1904 = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// excelSerialToTime converts an Excel date/time serial number to a
+// time.Time, honoring the workbook's date1904 flag.
+func excelSerialToTime(serial float64, date1904 bool) time.Time {
+	epoch := excelEpoch1900
+	if date1904 {
+		epoch = excelEpoch1904
+	}
+	days := int(serial)
+	seconds := int((serial-float64(days))*86400 + 0.5)
+	return epoch.AddDate(0, 0, days).Add(time.Duration(seconds) * time.Second)
+}
+
+// timeToExcelSerial is the inverse of excelSerialToTime, used by the writer
+// to turn a time.Time into the numeric value Excel expects in <v>.
+func timeToExcelSerial(t time.Time, date1904 bool) float64 {
+	epoch := excelEpoch1900
+	if date1904 {
+		epoch = excelEpoch1904
+	}
+	return t.Sub(epoch).Hours() / 24
+}
+
+// isDateFormatCode reports whether a number format code represents a date
+// or time, by looking for date/time tokens outside of quoted literals and
+// bracketed sections (colors, conditions).
+func isDateFormatCode(code string) bool {
+	inQuote, inBracket := false, false
+	for _, r := range code {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case r == '[':
+			inBracket = true
+		case r == ']':
+			inBracket = false
+		case inQuote || inBracket:
+		case r == 'y' || r == 'Y' || r == 'd' || r == 'D' || r == 'h' || r == 'H' || r == 's' || r == 'S':
+			return true
+		}
+	}
+	return false
+}
+
+// formatDate renders t according to the date/time tokens in code: yyyy/yy,
+// mmmm/mmm/mm/m (month, unless adjacent to an hour or seconds token, in
+// which case it means minutes), dd/d, hh/h, ss/s. Quoted and
+// backslash-escaped characters are passed through literally; bracketed
+// sections (elapsed-time markers like "[h]", or color/condition tags) are
+// dropped, matching isDateFormatCode; anything else is copied as-is.
+func formatDate(t time.Time, code string) string {
+	runes := []rune(code)
+	n := len(runes)
+	var b strings.Builder
+	sawHourOrSecond := false
+
+	for i := 0; i < n; {
+		r := runes[i]
+		switch r {
+		case '"':
+			i++
+			for i < n && runes[i] != '"' {
+				b.WriteRune(runes[i])
+				i++
+			}
+			i++
+			continue
+		case '\\':
+			if i+1 < n {
+				b.WriteRune(runes[i+1])
+				i += 2
+			} else {
+				i++
+			}
+			continue
+		case '[':
+			for i < n && runes[i] != ']' {
+				i++
+			}
+			if i < n {
+				i++
+			}
+			continue
+		}
+
+		if !strings.ContainsRune("yYmMdDhHsS", r) {
+			b.WriteRune(r)
+			i++
+			continue
+		}
+
+		j := i
+		for j < n && runes[j] == r {
+			j++
+		}
+		run := j - i
+
+		switch r {
+		case 'y', 'Y':
+			sawHourOrSecond = false
+			if run >= 4 {
+				b.WriteString(t.Format("2006"))
+			} else {
+				b.WriteString(t.Format("06"))
+			}
+		case 'd', 'D':
+			sawHourOrSecond = false
+			if run >= 2 {
+				fmt.Fprintf(&b, "%02d", t.Day())
+			} else {
+				b.WriteString(strconv.Itoa(t.Day()))
+			}
+		case 'h', 'H':
+			sawHourOrSecond = true
+			if run >= 2 {
+				fmt.Fprintf(&b, "%02d", t.Hour())
+			} else {
+				b.WriteString(strconv.Itoa(t.Hour()))
+			}
+		case 's', 'S':
+			sawHourOrSecond = true
+			if run >= 2 {
+				fmt.Fprintf(&b, "%02d", t.Second())
+			} else {
+				b.WriteString(strconv.Itoa(t.Second()))
+			}
+		case 'm', 'M':
+			if sawHourOrSecond || nextDateTokenIsHourOrSecond(runes, j) {
+				if run >= 2 {
+					fmt.Fprintf(&b, "%02d", t.Minute())
+				} else {
+					b.WriteString(strconv.Itoa(t.Minute()))
+				}
+			} else if run >= 3 {
+				b.WriteString(t.Format("Jan"))
+			} else if run >= 2 {
+				fmt.Fprintf(&b, "%02d", int(t.Month()))
+			} else {
+				b.WriteString(strconv.Itoa(int(t.Month())))
+			}
+			sawHourOrSecond = false
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// nextDateTokenIsHourOrSecond looks ahead from position i, skipping quoted,
+// backslash-escaped and bracketed sections and any non-token characters,
+// and reports whether the next y/m/d/h/s token it finds is an hour or
+// seconds token. It's used to recognize "mm" as minutes when it precedes
+// "ss" (as in the common "mm:ss" format), the mirror image of "mm"
+// following "hh".
+func nextDateTokenIsHourOrSecond(runes []rune, i int) bool {
+	n := len(runes)
+	for i < n {
+		switch r := runes[i]; {
+		case r == '"':
+			i++
+			for i < n && runes[i] != '"' {
+				i++
+			}
+			i++
+		case r == '\\':
+			i += 2
+		case r == '[':
+			for i < n && runes[i] != ']' {
+				i++
+			}
+			i++
+		case r == 'h' || r == 'H' || r == 's' || r == 'S':
+			return true
+		case strings.ContainsRune("yYmMdD", r):
+			return false
+		default:
+			i++
+		}
+	}
+	return false
+}
+
+// formatNumberCode renders value according to a non-date number format
+// code, handling percentages ("0.00%"), thousands separators ("#,##0") and
+// the number of decimal places implied by trailing zeros after the dot.
+func formatNumberCode(value float64, code string) string {
+	if strings.Contains(code, "%") {
+		value *= 100
+	}
+
+	decimals := 0
+	if dot := strings.IndexByte(code, '.'); dot >= 0 {
+		for _, r := range code[dot+1:] {
+			if r != '0' {
+				break
+			}
+			decimals++
+		}
+	}
+
+	s := strconv.FormatFloat(value, 'f', decimals, 64)
+	if strings.Contains(code, ",") {
+		s = addThousandsSeparators(s)
+	}
+	if strings.Contains(code, "%") {
+		s += "%"
+	}
+	return s
+}
+
+// addThousandsSeparators inserts "," every three digits in the integer
+// part of a formatted decimal number string.
+func addThousandsSeparators(s string) string {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	intPart, frac := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		intPart, frac = s[:dot], s[dot:]
+	}
+	for i := len(intPart) - 3; i > 0; i -= 3 {
+		intPart = intPart[:i] + "," + intPart[i:]
+	}
+	if neg {
+		return "-" + intPart + frac
+	}
+	return intPart + frac
+}
@@ -0,0 +1,86 @@
+package goxlsx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsDateFormatCode(t *testing.T) {
+	cases := map[string]bool{
+		"General":     false,
+		"0.00":        false,
+		"0.00%":       false,
+		"#,##0":       false,
+		"@":           false,
+		"yyyy-mm-dd":  true,
+		"h:mm:ss":     true,
+		"mm-dd-yy":    true,
+		`"EUR" 0.00`:  false,
+		"[Red]0.00":   false,
+		`[Red]"y"0.0`: false,
+	}
+	for code, want := range cases {
+		if got := isDateFormatCode(code); got != want {
+			t.Errorf("isDateFormatCode(%q) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	d := time.Date(2021, time.January, 1, 9, 5, 3, 0, time.UTC)
+	cases := map[string]string{
+		"yyyy-mm-dd": "2021-01-01",
+		"dd-mmm-yy":  "01-Jan-21",
+		"h:mm:ss":    "9:05:03",
+		"hh:mm":      "09:05",
+	}
+	for code, want := range cases {
+		if got := formatDate(d, code); got != want {
+			t.Errorf("formatDate(%v, %q) = %q, want %q", d, code, got, want)
+		}
+	}
+}
+
+func TestFormatDateMinutesBeforeSeconds(t *testing.T) {
+	// "mm" has no preceding hour token here, only a following seconds
+	// token, but it should still render as minutes rather than month.
+	d := time.Date(2021, time.March, 1, 3, 5, 30, 0, time.UTC)
+	if got, want := formatDate(d, "mm:ss"), "05:30"; got != want {
+		t.Errorf("formatDate(%v, mm:ss) = %q, want %q", d, got, want)
+	}
+}
+
+func TestFormatDateBracketedElapsedHours(t *testing.T) {
+	// The "[h]" elapsed-hours marker is dropped rather than leaked
+	// literally, and "mm" is still recognized as minutes since it
+	// precedes "ss".
+	d := time.Date(2021, time.March, 1, 3, 5, 30, 0, time.UTC)
+	if got, want := formatDate(d, "[h]:mm:ss"), ":05:30"; got != want {
+		t.Errorf("formatDate(%v, [h]:mm:ss) = %q, want %q", d, got, want)
+	}
+}
+
+func TestFormatNumberCode(t *testing.T) {
+	cases := []struct {
+		value float64
+		code  string
+		want  string
+	}{
+		{0.125, "0.00%", "12.50%"},
+		{1234567, "#,##0", "1,234,567"},
+		{1234.5, "#,##0.00", "1,234.50"},
+	}
+	for _, c := range cases {
+		if got := formatNumberCode(c.value, c.code); got != c.want {
+			t.Errorf("formatNumberCode(%v, %q) = %q, want %q", c.value, c.code, got, c.want)
+		}
+	}
+}
+
+func TestExcelSerialToTime(t *testing.T) {
+	got := excelSerialToTime(44197, false)
+	want := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("excelSerialToTime(44197, false) = %v, want %v", got, want)
+	}
+}
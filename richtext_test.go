@@ -0,0 +1,51 @@
+package goxlsx
+
+import "testing"
+
+func TestRichTextPlain(t *testing.T) {
+	rt := xlsxRichText{T: "hello"}
+	if got := richText(rt); got != "hello" {
+		t.Errorf("richText(plain) = %q, want %q", got, "hello")
+	}
+	runs := richRuns(rt)
+	if len(runs) != 1 || runs[0].Text != "hello" || runs[0].Bold {
+		t.Errorf("richRuns(plain) = %+v", runs)
+	}
+}
+
+func TestRichTextRuns(t *testing.T) {
+	rt := xlsxRichText{
+		R: []xlsxRun{
+			{T: "Hello "},
+			{T: "world", RPr: xlsxRPr{B: &struct{}{}, RFont: xlsxFontName{Val: "Calibri"}}},
+		},
+	}
+	if got, want := richText(rt), "Hello world"; got != want {
+		t.Errorf("richText(runs) = %q, want %q", got, want)
+	}
+	runs := richRuns(rt)
+	if len(runs) != 2 {
+		t.Fatalf("richRuns(runs) = %d runs, want 2", len(runs))
+	}
+	if runs[1].Text != "world" || !runs[1].Bold || runs[1].Font != "Calibri" {
+		t.Errorf("runs[1] = %+v", runs[1])
+	}
+	if runs[0].Bold {
+		t.Errorf("runs[0] should not be bold: %+v", runs[0])
+	}
+}
+
+func TestReadStringsNoUniqueCount(t *testing.T) {
+	const xml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="2">
+  <si><t>Foo</t></si>
+  <si><t>Bar</t></si>
+</sst>`
+	plain, rich := readStrings([]byte(xml))
+	if len(plain) != 2 || plain[0] != "Foo" || plain[1] != "Bar" {
+		t.Errorf("readStrings without uniqueCount = %v, want [Foo Bar]", plain)
+	}
+	if len(rich) != 2 {
+		t.Errorf("readStrings without uniqueCount returned %d rich entries, want 2", len(rich))
+	}
+}
@@ -0,0 +1,53 @@
+package goxlsx
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWorksheetStreamsWithoutFullParse(t *testing.T) {
+	s := NewFile()
+	ws := s.NewWorksheet("Sheet1")
+	ws.SetCell(1, 1, "A")
+	ws.SetCell(2, 1, "B")
+	ws.SetCell(1, 2, "C")
+
+	var buf bytes.Buffer
+	if err := s.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := OpenReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := reopened.Worksheet(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.rows != nil {
+		t.Error("Worksheet should not parse rows into memory")
+	}
+
+	it, err := meta.Rows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows [][]string
+	for it.Next() {
+		rows = append(rows, it.Row())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0][0] != "A" || rows[0][1] != "B" {
+		t.Errorf("row 1 = %v, want [A B]", rows[0])
+	}
+	if rows[1][0] != "C" {
+		t.Errorf("row 2 = %v, want [C]", rows[1])
+	}
+}
@@ -9,13 +9,57 @@ type sheet struct {
 	SheetID string `xml:"sheetId,attr"`
 	Rid     string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
 }
+
+// xlsxWorkbookPr carries the workbook-wide settings needed by the reader,
+// in particular which date epoch ("date system") serial date values use.
+type xlsxWorkbookPr struct {
+	Date1904 bool `xml:"date1904,attr"`
+}
+
 type workbook struct {
-	XMLName xml.Name `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main workbook"`
-	Sheets  []sheet  `xml:"sheets>sheet"`
+	XMLName    xml.Name       `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main workbook"`
+	WorkbookPr xlsxWorkbookPr `xml:"workbookPr"`
+	Sheets     []sheet        `xml:"sheets>sheet"`
+}
+
+// xlsxFontName is the <rFont val="..."/> child of a run's rPr.
+type xlsxFontName struct {
+	Val string `xml:"val,attr"`
+}
+
+// xlsxColor is a <color rgb="FFRRGGBB"/> or <color theme="n"/> element; only
+// the direct RGB value is surfaced via RichRun.Color today.
+type xlsxColor struct {
+	RGB string `xml:"rgb,attr"`
+}
+
+// xlsxRPr is the run properties of a rich text run: <b/> and <i/> are empty
+// elements whose mere presence means true.
+type xlsxRPr struct {
+	B     *struct{}    `xml:"b"`
+	I     *struct{}    `xml:"i"`
+	RFont xlsxFontName `xml:"rFont"`
+	Color xlsxColor    `xml:"color"`
+}
+
+// xlsxRun is one <r> run inside a rich text value (<si> or <is>): a run of
+// text sharing the same formatting.
+type xlsxRun struct {
+	T   string  `xml:"t"`
+	RPr xlsxRPr `xml:"rPr"`
+}
+
+// xlsxRichText is the shape shared by <si> (shared string table entries)
+// and <is> (inline string cell values): either a single unformatted <t>, or
+// one or more formatted <r> runs. Go's xml.Unmarshal keeps all whitespace
+// in character data, so xml:space="preserve" needs no special handling.
+type xlsxRichText struct {
+	T string    `xml:"t"`
+	R []xlsxRun `xml:"r"`
 }
 
 type si struct {
-	T string `xml:"t"`
+	xlsxRichText
 }
 type sst struct {
 	XMLName     xml.Name `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main sst"`
@@ -24,11 +68,33 @@ type sst struct {
 	Si          []si     `xml:"si"`
 }
 
-type xlsxColumn struct {
-	R    string `xml:"r,attr"`
+// xlsxFormula is the <f> child of a cell. A plain formula carries its
+// expression as character data; a shared formula's master cell carries
+// both the expression and a ref/si pair identifying the group, while the
+// group's other cells carry only t="shared" and the matching si.
+type xlsxFormula struct {
 	T    string `xml:"t,attr"`
-	V    string `xml:"v"`
-	Text string `xml:"is>t"`
+	Ref  string `xml:"ref,attr"`
+	Si   string `xml:"si,attr"`
+	Expr string `xml:",chardata"`
+}
+
+type xlsxColumn struct {
+	R  string       `xml:"r,attr"`
+	T  string       `xml:"t,attr"`
+	S  string       `xml:"s,attr"`
+	V  string       `xml:"v"`
+	F  xlsxFormula  `xml:"f"`
+	Is xlsxRichText `xml:"is"`
+}
+
+// xlsxMergeCell is one <mergeCell ref="A1:B2"/> entry.
+type xlsxMergeCell struct {
+	Ref string `xml:"ref,attr"`
+}
+
+type xlsxMergeCells struct {
+	Cell []xlsxMergeCell `xml:"mergeCell"`
 }
 type xlsxRow struct {
 	Rownumber int          `xml:"r,attr"`
@@ -40,9 +106,10 @@ type xslxDimension struct {
 }
 
 type xlsxWorksheet struct {
-	XMLName   xml.Name      `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main worksheet"`
-	Dimension xslxDimension `xml:"dimension"`
-	Row       []xlsxRow     `xml:"sheetData>row"`
+	XMLName    xml.Name       `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main worksheet"`
+	Dimension  xslxDimension  `xml:"dimension"`
+	Row        []xlsxRow      `xml:"sheetData>row"`
+	MergeCells xlsxMergeCells `xml:"mergeCells"`
 }
 
 type xslxRelationship struct {
@@ -60,3 +127,23 @@ type relationship struct {
 	Type   string `xml:"Type,attr"`
 	Target string `xml:"Target,attr"`
 }
+
+// xlsxNumFmt is a custom number format declared in xl/styles.xml. Format IDs
+// below 164 are reserved for the builtin formats and normally aren't present
+// here; see builtinNumFmts for those.
+type xlsxNumFmt struct {
+	NumFmtID   int    `xml:"numFmtId,attr"`
+	FormatCode string `xml:"formatCode,attr"`
+}
+
+// xlsxXf is a cell format record; its index within styleSheet.CellXfs is
+// what a cell's `s` attribute refers to.
+type xlsxXf struct {
+	NumFmtID int `xml:"numFmtId,attr"`
+}
+
+type xlsxStyleSheet struct {
+	XMLName xml.Name     `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main styleSheet"`
+	NumFmts []xlsxNumFmt `xml:"numFmts>numFmt"`
+	CellXfs []xlsxXf     `xml:"cellXfs>xf"`
+}
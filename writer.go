@@ -0,0 +1,507 @@
+package goxlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewFile creates an empty, writable Spreadsheet with no worksheets. Add
+// worksheets with NewWorksheet, then save with SaveAs or Write.
+func NewFile() *Spreadsheet {
+	return &Spreadsheet{}
+}
+
+// NewWorksheet appends a new, empty worksheet named name to s and returns
+// it for writing with SetCell, SetCellFormula and SetCellStyle.
+func (s *Spreadsheet) NewWorksheet(name string) *Worksheet {
+	ws := &Worksheet{
+		Name:        name,
+		spreadsheet: s,
+		rows:        make(map[int]*row),
+	}
+	s.worksheets = append(s.worksheets, ws)
+	return ws
+}
+
+// ensureCell returns the cell at column, row, creating its row and
+// expanding the worksheet's bounds as needed.
+func (ws *Worksheet) ensureCell(column, rowNum int) *cell {
+	r := ws.rows[rowNum]
+	if r == nil {
+		r = &row{Num: rowNum, Cells: make(map[int]*cell)}
+		ws.rows[rowNum] = r
+	}
+	c := r.Cells[column]
+	if c == nil {
+		c = &cell{NumFmt: -1}
+		r.Cells[column] = c
+	}
+	if ws.MinColumn == 0 || column < ws.MinColumn {
+		ws.MinColumn = column
+	}
+	if column > ws.MaxColumn {
+		ws.MaxColumn = column
+	}
+	if ws.MinRow == 0 || rowNum < ws.MinRow {
+		ws.MinRow = rowNum
+	}
+	if rowNum > ws.MaxRow {
+		ws.MaxRow = rowNum
+	}
+	return c
+}
+
+// SetCell sets the value of a cell, creating its row and column as needed.
+// string, bool and time.Time are recognized specially; any other numeric
+// kind (int, float64, ...) is stored as a number, and anything else is
+// rendered with fmt.Sprint and stored as a string.
+func (ws *Worksheet) SetCell(column, rowNum int, v interface{}) {
+	c := ws.ensureCell(column, rowNum)
+	c.Formula = ""
+	switch val := v.(type) {
+	case string:
+		c.Type = "s"
+		c.Value = val
+	case bool:
+		c.Type = "b"
+		if val {
+			c.Value = "1"
+		} else {
+			c.Value = "0"
+		}
+	case time.Time:
+		c.Type = "n"
+		c.Value = strconv.FormatFloat(timeToExcelSerial(val, ws.spreadsheet.date1904), 'f', -1, 64)
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			c.Type, c.Value = "n", strconv.FormatInt(rv.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			c.Type, c.Value = "n", strconv.FormatUint(rv.Uint(), 10)
+		case reflect.Float32, reflect.Float64:
+			c.Type, c.Value = "n", strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+		default:
+			c.Type, c.Value = "s", fmt.Sprint(v)
+		}
+	}
+}
+
+// SetCellFormula sets a cell's formula text, replacing any value set with
+// SetCell. No cached value is written for it; Excel recalculates the
+// formula the next time the workbook is opened.
+func (ws *Worksheet) SetCellFormula(column, rowNum int, formula string) {
+	c := ws.ensureCell(column, rowNum)
+	c.Type, c.Value = "", ""
+	c.Formula = formula
+}
+
+// SetCellStyle assigns a number format id to a cell (see builtinNumFmts for
+// the builtin ids 0-49), controlling how CellValue renders it and how
+// Excel displays it.
+func (ws *Worksheet) SetCellStyle(column, rowNum int, numFmtID int) {
+	c := ws.ensureCell(column, rowNum)
+	c.NumFmt = numFmtID
+}
+
+// SaveAs writes s to a new file at path.
+func (s *Spreadsheet) SaveAs(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return s.Write(f)
+}
+
+// Write serializes s as a complete xlsx archive to w: [Content_Types].xml,
+// the package and workbook relationships, xl/workbook.xml, a deduplicated
+// xl/sharedStrings.xml, xl/styles.xml and one xl/worksheets/sheetN.xml per
+// worksheet.
+func (s *Spreadsheet) Write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	sheetNames := make([]string, len(s.worksheets))
+	for i, ws := range s.worksheets {
+		sheetNames[i] = ws.Name
+	}
+
+	sharedStrings, stringIndex := s.buildSharedStrings()
+	numFmtIDs := s.collectCellStyles()
+	xfIndexForNumFmt := make(map[int]int, len(numFmtIDs))
+	for i, id := range numFmtIDs {
+		xfIndexForNumFmt[id] = i + 1 // xf 0 is reserved for the default "General" style.
+	}
+
+	if err := writeContentTypes(zw, len(s.worksheets), len(sharedStrings) > 0); err != nil {
+		return err
+	}
+	if err := writeRootRels(zw); err != nil {
+		return err
+	}
+	if err := writeWorkbook(zw, sheetNames); err != nil {
+		return err
+	}
+	if err := writeWorkbookRels(zw, len(s.worksheets), len(sharedStrings) > 0); err != nil {
+		return err
+	}
+	if err := writeStyles(zw, numFmtIDs); err != nil {
+		return err
+	}
+	if len(sharedStrings) > 0 {
+		if err := writeSharedStrings(zw, sharedStrings); err != nil {
+			return err
+		}
+	}
+	for i, ws := range s.worksheets {
+		if err := ws.writeXML(zw, i+1, stringIndex, xfIndexForNumFmt); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func sortedRowNumbers(rows map[int]*row) []int {
+	nums := make([]int, 0, len(rows))
+	for n := range rows {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+func sortedCellColumns(cells map[int]*cell) []int {
+	nums := make([]int, 0, len(cells))
+	for n := range cells {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// buildSharedStrings collects every distinct "s"-typed cell value across
+// all worksheets, in first-seen (row-major) order, for xl/sharedStrings.xml.
+func (s *Spreadsheet) buildSharedStrings() ([]string, map[string]int) {
+	index := make(map[string]int)
+	var list []string
+	for _, ws := range s.worksheets {
+		for _, rn := range sortedRowNumbers(ws.rows) {
+			r := ws.rows[rn]
+			for _, cn := range sortedCellColumns(r.Cells) {
+				c := r.Cells[cn]
+				if c.Type != "s" {
+					continue
+				}
+				if _, ok := index[c.Value]; ok {
+					continue
+				}
+				index[c.Value] = len(list)
+				list = append(list, c.Value)
+			}
+		}
+	}
+	return list, index
+}
+
+// collectCellStyles returns the distinct number format ids assigned via
+// SetCellStyle, sorted, so each gets exactly one cellXfs entry.
+func (s *Spreadsheet) collectCellStyles() []int {
+	seen := make(map[int]bool)
+	var ids []int
+	for _, ws := range s.worksheets {
+		for _, r := range ws.rows {
+			for _, c := range r.Cells {
+				if c.NumFmt < 0 || seen[c.NumFmt] {
+					continue
+				}
+				seen[c.NumFmt] = true
+				ids = append(ids, c.NumFmt)
+			}
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func writeContentTypes(zw *zip.Writer, numSheets int, hasSharedStrings bool) error {
+	f, err := zw.Create("[Content_Types].xml")
+	if err != nil {
+		return err
+	}
+	io.WriteString(f, xml.Header)
+	io.WriteString(f, `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	io.WriteString(f, `<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	io.WriteString(f, `<Default Extension="xml" ContentType="application/xml"/>`)
+	io.WriteString(f, `<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	io.WriteString(f, `<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	if hasSharedStrings {
+		io.WriteString(f, `<Override PartName="/xl/sharedStrings.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"/>`)
+	}
+	for i := 1; i <= numSheets; i++ {
+		fmt.Fprintf(f, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	io.WriteString(f, `</Types>`)
+	return nil
+}
+
+func writeRootRels(zw *zip.Writer) error {
+	f, err := zw.Create("_rels/.rels")
+	if err != nil {
+		return err
+	}
+	io.WriteString(f, xml.Header)
+	io.WriteString(f, `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	io.WriteString(f, `<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>`)
+	io.WriteString(f, `</Relationships>`)
+	return nil
+}
+
+func writeWorkbook(zw *zip.Writer, sheetNames []string) error {
+	f, err := zw.Create("xl/workbook.xml")
+	if err != nil {
+		return err
+	}
+	io.WriteString(f, xml.Header)
+	io.WriteString(f, `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	io.WriteString(f, `<sheets>`)
+	for i, name := range sheetNames {
+		var escaped strings.Builder
+		xml.EscapeText(&escaped, []byte(name))
+		fmt.Fprintf(f, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escaped.String(), i+1, i+1)
+	}
+	io.WriteString(f, `</sheets>`)
+	io.WriteString(f, `</workbook>`)
+	return nil
+}
+
+func writeWorkbookRels(zw *zip.Writer, numSheets int, hasSharedStrings bool) error {
+	f, err := zw.Create("xl/_rels/workbook.xml.rels")
+	if err != nil {
+		return err
+	}
+	io.WriteString(f, xml.Header)
+	io.WriteString(f, `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= numSheets; i++ {
+		fmt.Fprintf(f, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	nextID := numSheets + 1
+	fmt.Fprintf(f, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, nextID)
+	if hasSharedStrings {
+		nextID++
+		fmt.Fprintf(f, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>`, nextID)
+	}
+	io.WriteString(f, `</Relationships>`)
+	return nil
+}
+
+func writeStyles(zw *zip.Writer, numFmtIDs []int) error {
+	f, err := zw.Create("xl/styles.xml")
+	if err != nil {
+		return err
+	}
+	io.WriteString(f, xml.Header)
+	io.WriteString(f, `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	io.WriteString(f, `<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>`)
+	io.WriteString(f, `<fills count="1"><fill><patternFill patternType="none"/></fill></fills>`)
+	io.WriteString(f, `<borders count="1"><border/></borders>`)
+	io.WriteString(f, `<cellStyleXfs count="1"><xf numFmtId="0"/></cellStyleXfs>`)
+	fmt.Fprintf(f, `<cellXfs count="%d">`, len(numFmtIDs)+1)
+	io.WriteString(f, `<xf numFmtId="0" fontId="0" fillId="0" borderId="0"/>`)
+	for _, id := range numFmtIDs {
+		fmt.Fprintf(f, `<xf numFmtId="%d" fontId="0" fillId="0" borderId="0" applyNumberFormat="1"/>`, id)
+	}
+	io.WriteString(f, `</cellXfs>`)
+	io.WriteString(f, `</styleSheet>`)
+	return nil
+}
+
+func writeSharedStrings(zw *zip.Writer, strs []string) error {
+	f, err := zw.Create("xl/sharedStrings.xml")
+	if err != nil {
+		return err
+	}
+	io.WriteString(f, xml.Header)
+	fmt.Fprintf(f, `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="%d" uniqueCount="%d">`, len(strs), len(strs))
+	for _, str := range strs {
+		io.WriteString(f, `<si><t`)
+		if str != strings.TrimSpace(str) {
+			io.WriteString(f, ` xml:space="preserve"`)
+		}
+		io.WriteString(f, `>`)
+		xml.EscapeText(f, []byte(str))
+		io.WriteString(f, `</t></si>`)
+	}
+	io.WriteString(f, `</sst>`)
+	return nil
+}
+
+// writeXML renders ws as xl/worksheets/sheetN.xml, where N is sheetIndex
+// (1-based).
+func (ws *Worksheet) writeXML(zw *zip.Writer, sheetIndex int, stringIndex map[string]int, xfIndexForNumFmt map[int]int) error {
+	f, err := zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", sheetIndex))
+	if err != nil {
+		return err
+	}
+	io.WriteString(f, xml.Header)
+	io.WriteString(f, `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if ws.MaxRow > 0 {
+		ref := CoordinatesToCellName(ws.MinColumn, ws.MinRow) + ":" + CoordinatesToCellName(ws.MaxColumn, ws.MaxRow)
+		fmt.Fprintf(f, `<dimension ref="%s"/>`, ref)
+	}
+	io.WriteString(f, `<sheetData>`)
+	for _, rn := range sortedRowNumbers(ws.rows) {
+		r := ws.rows[rn]
+		fmt.Fprintf(f, `<row r="%d">`, rn)
+		for _, cn := range sortedCellColumns(r.Cells) {
+			if err := writeCellXML(f, r.Cells[cn], cn, rn, stringIndex, xfIndexForNumFmt); err != nil {
+				return err
+			}
+		}
+		io.WriteString(f, `</row>`)
+	}
+	io.WriteString(f, `</sheetData></worksheet>`)
+	return nil
+}
+
+func writeCellXML(f io.Writer, c *cell, column, rowNum int, stringIndex map[string]int, xfIndexForNumFmt map[int]int) error {
+	ref := CoordinatesToCellName(column, rowNum)
+	fmt.Fprintf(f, `<c r="%s"`, ref)
+	if c.NumFmt >= 0 {
+		if xf, ok := xfIndexForNumFmt[c.NumFmt]; ok {
+			fmt.Fprintf(f, ` s="%d"`, xf)
+		}
+	}
+	switch c.Type {
+	case "s":
+		fmt.Fprintf(f, ` t="s"><v>%d</v></c>`, stringIndex[c.Value])
+	case "b":
+		fmt.Fprintf(f, ` t="b"><v>%s</v></c>`, c.Value)
+	default:
+		io.WriteString(f, `>`)
+		if c.Formula != "" {
+			io.WriteString(f, `<f>`)
+			xml.EscapeText(f, []byte(c.Formula))
+			io.WriteString(f, `</f>`)
+		}
+		if c.Value != "" {
+			fmt.Fprintf(f, `<v>%s</v>`, c.Value)
+		}
+		io.WriteString(f, `</c>`)
+	}
+	return nil
+}
+
+// RowStreamWriter writes a single worksheet's rows directly to the output
+// archive as they arrive, instead of buffering the whole sheet as an
+// in-memory *Worksheet, so exporting multi-million-row sheets has bounded
+// memory. It only supports a standalone single-sheet workbook today; ws
+// must be the only worksheet in its Spreadsheet.
+type RowStreamWriter struct {
+	zw       *zip.Writer
+	sheetW   io.Writer
+	rowNum   int
+	date1904 bool
+	closed   bool
+}
+
+// StreamRows begins a streaming write of ws to w, writing every static
+// part of the archive up front and leaving xl/worksheets/sheet1.xml open
+// for WriteRow to append to.
+func (ws *Worksheet) StreamRows(w io.Writer) (*RowStreamWriter, error) {
+	if len(ws.spreadsheet.worksheets) != 1 {
+		return nil, fmt.Errorf("StreamRows requires ws to be the only worksheet in its Spreadsheet")
+	}
+
+	zw := zip.NewWriter(w)
+	if err := writeContentTypes(zw, 1, false); err != nil {
+		return nil, err
+	}
+	if err := writeRootRels(zw); err != nil {
+		return nil, err
+	}
+	if err := writeWorkbook(zw, []string{ws.Name}); err != nil {
+		return nil, err
+	}
+	if err := writeWorkbookRels(zw, 1, false); err != nil {
+		return nil, err
+	}
+	if err := writeStyles(zw, nil); err != nil {
+		return nil, err
+	}
+
+	sheetW, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	io.WriteString(sheetW, xml.Header)
+	io.WriteString(sheetW, `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	return &RowStreamWriter{zw: zw, sheetW: sheetW, date1904: ws.spreadsheet.date1904}, nil
+}
+
+// WriteRow appends one row of values (see SetCell for the accepted types)
+// and writes its XML straight to the worksheet's archive entry; no row is
+// kept in memory afterwards.
+func (sw *RowStreamWriter) WriteRow(values []interface{}) error {
+	sw.rowNum++
+	fmt.Fprintf(sw.sheetW, `<row r="%d">`, sw.rowNum)
+	for i, v := range values {
+		ref := CoordinatesToCellName(i+1, sw.rowNum)
+		switch val := v.(type) {
+		case string:
+			fmt.Fprintf(sw.sheetW, `<c r="%s" t="inlineStr"><is><t>`, ref)
+			xml.EscapeText(sw.sheetW, []byte(val))
+			io.WriteString(sw.sheetW, `</t></is></c>`)
+		case bool:
+			n := "0"
+			if val {
+				n = "1"
+			}
+			fmt.Fprintf(sw.sheetW, `<c r="%s" t="b"><v>%s</v></c>`, ref, n)
+		case time.Time:
+			serial := strconv.FormatFloat(timeToExcelSerial(val, sw.date1904), 'f', -1, 64)
+			fmt.Fprintf(sw.sheetW, `<c r="%s"><v>%s</v></c>`, ref, serial)
+		default:
+			rv := reflect.ValueOf(v)
+			var numeric string
+			switch rv.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				numeric = strconv.FormatInt(rv.Int(), 10)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				numeric = strconv.FormatUint(rv.Uint(), 10)
+			case reflect.Float32, reflect.Float64:
+				numeric = strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+			}
+			if numeric != "" {
+				fmt.Fprintf(sw.sheetW, `<c r="%s"><v>%s</v></c>`, ref, numeric)
+				continue
+			}
+			fmt.Fprintf(sw.sheetW, `<c r="%s" t="inlineStr"><is><t>`, ref)
+			xml.EscapeText(sw.sheetW, []byte(fmt.Sprint(v)))
+			io.WriteString(sw.sheetW, `</t></is></c>`)
+		}
+	}
+	_, err := io.WriteString(sw.sheetW, `</row>`)
+	return err
+}
+
+// Close finishes the worksheet XML and the archive. Call it exactly once,
+// after the last WriteRow.
+func (sw *RowStreamWriter) Close() error {
+	if sw.closed {
+		return nil
+	}
+	sw.closed = true
+	if _, err := io.WriteString(sw.sheetW, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return sw.zw.Close()
+}
@@ -0,0 +1,133 @@
+package goxlsx
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWriteAndReopen(t *testing.T) {
+	s := NewFile()
+	ws := s.NewWorksheet("Sheet1")
+	ws.SetCell(1, 1, "A")
+	ws.SetCell(2, 1, "B")
+	ws.SetCell(1, 2, 42)
+	ws.SetCell(2, 2, "A") // repeated string should reuse the same shared string entry
+	ws.SetCellFormula(3, 2, "A1&B1")
+
+	var buf bytes.Buffer
+	if err := s.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := OpenReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.NumWorksheets() != 1 {
+		t.Error("num of worksheets != 1")
+	}
+
+	got, err := reopened.GetWorksheet(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Cell(1, 1) != "A" {
+		t.Error("1,1 should be A")
+	}
+	if got.Cell(2, 1) != "B" {
+		t.Error("2,1 should be B")
+	}
+	if got.Cell(1, 2) != "42" {
+		t.Error("1,2 should be 42")
+	}
+	if got.Cell(2, 2) != "A" {
+		t.Error("2,2 should be A")
+	}
+	if got.Formula(3, 2) != "A1&B1" {
+		t.Error("formula mismatch, got", got.Formula(3, 2))
+	}
+
+	if len(reopened.sharedStrings) != 2 {
+		t.Error("shared strings should be deduplicated, got", reopened.sharedStrings)
+	}
+}
+
+func TestStreamRows(t *testing.T) {
+	s := NewFile()
+	ws := s.NewWorksheet("Sheet1")
+
+	var buf bytes.Buffer
+	sw, err := ws.StreamRows(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if err := sw.WriteRow([]interface{}{"A & B", 1, true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.WriteRow([]interface{}{"B", 2, false, d}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := OpenReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reopened.GetWorksheet(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Cell(1, 1) != "A & B" {
+		t.Error("1,1 should be A & B, got", got.Cell(1, 1))
+	}
+	if got.Cell(2, 1) != "1" {
+		t.Error("2,1 should be 1")
+	}
+	boolVal, err := got.CellValue(3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if boolVal != "FALSE" {
+		t.Error("3,2 should be FALSE")
+	}
+	gotTime, err := got.CellTime(4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotTime.Equal(d) {
+		t.Errorf("4,2 = %v, want %v", gotTime, d)
+	}
+}
+
+func TestSetCellStyle(t *testing.T) {
+	s := NewFile()
+	ws := s.NewWorksheet("Sheet1")
+	d := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	ws.SetCell(1, 1, d)
+	ws.SetCellStyle(1, 1, 14) // builtin "mm-dd-yy" date format
+
+	var buf bytes.Buffer
+	if err := s.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := OpenReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reopened.GetWorksheet(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, err := got.CellValue(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "03-15-24"; val != want {
+		t.Errorf("CellValue(1,1) = %q, want %q", val, want)
+	}
+}
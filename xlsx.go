@@ -9,6 +9,7 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Worksheet represents a single worksheet in an excel file.
@@ -23,13 +24,19 @@ type Worksheet struct {
 	id          string
 	rid         string
 	rows        map[int]*row
+	mergedCells []CellRange
 	spreadsheet *Spreadsheet
 }
 
 type cell struct {
-	Name  string
-	Type  string
-	Value string
+	Name   string
+	Type   string
+	Value  string
+	NumFmt int
+	// richRuns holds formatted runs for "s" (shared, via the string table)
+	// and "inlineStr" cells; nil for every other cell type.
+	richRuns []RichRun
+	Formula  string
 }
 
 type row struct {
@@ -39,12 +46,16 @@ type row struct {
 
 // Spreadsheet represents the whole .xlsx file.
 type Spreadsheet struct {
-	filepath          string
-	compressedFiles   []zip.File
-	worksheets        []*Worksheet
-	sharedStrings     []string
-	uncompressedFiles map[string][]byte
-	relationships     map[string]relationship
+	filepath      string
+	closer        io.Closer
+	zipFiles      map[string]*zip.File
+	worksheets    []*Worksheet
+	sharedStrings []string
+	sharedRuns    [][]RichRun
+	relationships map[string]relationship
+	date1904      bool
+	numFmtCodes   map[int]string
+	cellXfNumFmt  []int
 }
 
 // NumWorksheets returns the number of worksheets in a file.
@@ -52,6 +63,15 @@ func (s *Spreadsheet) NumWorksheets() int {
 	return len(s.worksheets)
 }
 
+// Close releases the underlying zip archive. It is a no-op for spreadsheets
+// opened with OpenReader, since the caller owns the io.ReaderAt in that case.
+func (s *Spreadsheet) Close() error {
+	if s.closer != nil {
+		return s.closer.Close()
+	}
+	return nil
+}
+
 func readWorkbook(data []byte, s *Spreadsheet) ([]*Worksheet, error) {
 	wb := &workbook{}
 	err := xml.Unmarshal(data, wb)
@@ -61,76 +81,201 @@ func readWorkbook(data []byte, s *Spreadsheet) ([]*Worksheet, error) {
 
 	var worksheets []*Worksheet
 
+	s.date1904 = wb.WorkbookPr.Date1904
+
 	for i := 0; i < len(wb.Sheets); i++ {
 		w := &Worksheet{}
 		w.spreadsheet = s
 		w.Name = wb.Sheets[i].Name
 		w.id = wb.Sheets[i].SheetID
 		w.rid = wb.Sheets[i].Rid
+		w.filename = "xl/" + s.relationships[w.rid].Target
 		worksheets = append(worksheets, w)
 	}
 	return worksheets, nil
 }
 
-func readStrings(data []byte) []string {
+// readStyles parses xl/styles.xml, recording the custom number formats and
+// the numFmtId each cellXfs entry points to. data may be nil if the archive
+// has no styles.xml, in which case every cell uses the default ("General")
+// format.
+func readStyles(data []byte, s *Spreadsheet) error {
+	if data == nil {
+		return nil
+	}
+	ss := &xlsxStyleSheet{}
+	if err := xml.Unmarshal(data, ss); err != nil {
+		return err
+	}
+	s.numFmtCodes = make(map[int]string, len(ss.NumFmts))
+	for _, nf := range ss.NumFmts {
+		s.numFmtCodes[nf.NumFmtID] = nf.FormatCode
+	}
+	s.cellXfNumFmt = make([]int, len(ss.CellXfs))
+	for i, xf := range ss.CellXfs {
+		s.cellXfNumFmt[i] = xf.NumFmtID
+	}
+	return nil
+}
+
+// richText concatenates the text of all <r> runs of a rich text value, or
+// returns its plain <t> if it has no runs.
+func richText(rt xlsxRichText) string {
+	if len(rt.R) == 0 {
+		return rt.T
+	}
+	var b strings.Builder
+	for _, r := range rt.R {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+// richRuns converts a rich text value's runs into RichRun values. A value
+// with no <r> runs (a plain <t>) is returned as a single unformatted run.
+func richRuns(rt xlsxRichText) []RichRun {
+	if len(rt.R) == 0 {
+		if rt.T == "" {
+			return nil
+		}
+		return []RichRun{{Text: rt.T}}
+	}
+	runs := make([]RichRun, len(rt.R))
+	for i, r := range rt.R {
+		runs[i] = RichRun{
+			Text:   r.T,
+			Bold:   r.RPr.B != nil,
+			Italic: r.RPr.I != nil,
+			Font:   r.RPr.RFont.Val,
+			Color:  r.RPr.Color.RGB,
+		}
+	}
+	return runs
+}
+
+func readStrings(data []byte) ([]string, [][]RichRun) {
 	sst := &sst{}
 	xml.Unmarshal(data, sst)
-	ret := make([]string, sst.UniqueCount)
-	for i := 0; i < sst.UniqueCount; i++ {
-		ret[i] = sst.Si[i].T
+	// UniqueCount is optional; build the tables from the actual <si> entries
+	// rather than trusting it, so a producer that omits it doesn't leave us
+	// with empty tables.
+	plain := make([]string, len(sst.Si))
+	rich := make([][]RichRun, len(sst.Si))
+	for i, si := range sst.Si {
+		plain[i] = richText(si.xlsxRichText)
+		rich[i] = richRuns(si.xlsxRichText)
 	}
-	return ret
+	return plain, rich
 }
 
 // OpenFile reads a file located at the given path and returns a spreadsheet object.
+// Only the workbook metadata, the shared strings table and the styles are read eagerly;
+// individual worksheets are read on demand by GetWorksheet and Worksheet.Rows.
+// Call Close when done with the returned Spreadsheet to release the archive.
 func OpenFile(path string) (*Spreadsheet, error) {
-	xlsx := new(Spreadsheet)
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	xlsx, err := newSpreadsheet(&r.Reader)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
 	xlsx.filepath = path
-	xlsx.uncompressedFiles = make(map[string][]byte)
+	xlsx.closer = r
+	return xlsx, nil
+}
 
-	r, err := zip.OpenReader(path)
+// OpenReader reads an xlsx file from r, which must support random access
+// (e.g. a network or blob source wrapped in a bytes.Reader or os.File),
+// without requiring a local copy of the whole archive on disk. The caller
+// remains responsible for closing r; Spreadsheet.Close is a no-op in this case.
+func OpenReader(r io.ReaderAt, size int64) (*Spreadsheet, error) {
+	zr, err := zip.NewReader(r, size)
 	if err != nil {
 		return nil, err
 	}
-	defer r.Close()
+	return newSpreadsheet(zr)
+}
 
-	for _, f := range r.File {
-		buf := make([]byte, f.UncompressedSize64)
-		rc, err := f.Open()
-		if err != nil {
-			return nil, err
-		}
-		pos := 0
-	readfile:
-		for {
-			size, err := rc.Read(buf[pos:])
-			if err == io.EOF {
-				// ok, fine
-				break readfile
-			} else if err != nil {
-				return nil, err
-			}
-			pos += size
-		}
-		if pos != int(f.UncompressedSize64) {
-			return nil, fmt.Errorf("read (%d) not equal to uncompressed size (%d)", pos, f.UncompressedSize64)
-		}
+func newSpreadsheet(zr *zip.Reader) (*Spreadsheet, error) {
+	xlsx := new(Spreadsheet)
+	xlsx.zipFiles = make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		xlsx.zipFiles[f.Name] = f
+	}
 
-		xlsx.uncompressedFiles[f.Name] = buf
+	relData, err := xlsx.readZipFile("xl/_rels/workbook.xml.rels")
+	if err != nil {
+		return nil, err
+	}
+	xlsx.relationships, err = readRelationships(relData)
+	if err != nil {
+		return nil, err
+	}
+
+	wbData, err := xlsx.readZipFile("xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+	xlsx.worksheets, err = readWorkbook(wbData, xlsx)
+	if err != nil {
+		return nil, err
 	}
-	xlsx.relationships, err = readRelationships(xlsx.uncompressedFiles["xl/_rels/workbook.xml.rels"])
+
+	sstData, err := xlsx.readZipFile("xl/sharedStrings.xml")
 	if err != nil {
 		return nil, err
 	}
-	xlsx.worksheets, err = readWorkbook(xlsx.uncompressedFiles["xl/workbook.xml"], xlsx)
+	if sstData != nil {
+		xlsx.sharedStrings, xlsx.sharedRuns = readStrings(sstData)
+	}
+
+	stylesData, err := xlsx.readZipFile("xl/styles.xml")
 	if err != nil {
 		return nil, err
 	}
-	xlsx.sharedStrings = readStrings(xlsx.uncompressedFiles["xl/sharedStrings.xml"])
+	if err := readStyles(stylesData, xlsx); err != nil {
+		return nil, err
+	}
 
 	return xlsx, nil
 }
 
+// numFmtCode resolves a cellXfs index (a cell's `s` attribute) to its
+// number format code, preferring a custom format declared in styles.xml and
+// falling back to the builtin format for that id.
+func (s *Spreadsheet) numFmtCode(xfIndex int) string {
+	if xfIndex < 0 || xfIndex >= len(s.cellXfNumFmt) {
+		return "General"
+	}
+	id := s.cellXfNumFmt[xfIndex]
+	if code, ok := s.numFmtCodes[id]; ok {
+		return code
+	}
+	if code, ok := builtinNumFmts[id]; ok {
+		return code
+	}
+	return "General"
+}
+
+// readZipFile returns the uncompressed contents of the named zip entry, or
+// nil if the archive does not contain it (some workbooks have no
+// sharedStrings.xml, for example).
+func (s *Spreadsheet) readZipFile(name string) ([]byte, error) {
+	f, ok := s.zipFiles[name]
+	if !ok {
+		return nil, nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
 func readRelationships(data []byte) (map[string]relationship, error) {
 	rels := &xslxRelationships{}
 	err := xml.Unmarshal(data, rels)
@@ -144,23 +289,42 @@ func readRelationships(data []byte) (map[string]relationship, error) {
 	return ret, nil
 }
 
-// excelpos is something like "AC101"
-func stringToPosition(excelpos string) (int, int) {
-	var columnnumber, rownumber rune
-	for _, v := range excelpos {
-		if v >= 'A' && v <= 'Z' {
-			columnnumber = columnnumber*26 + v - 'A' + 1
-		}
-		if v >= '0' && v <= '9' {
-			rownumber = rownumber*10 + v - '0'
-		}
-	}
-	return int(columnnumber), int(rownumber)
+// CellOption configures optional Cell lookup behavior.
+type CellOption func(*cellOpts)
+
+type cellOpts struct {
+	expandMerged bool
+}
+
+// ExpandMergedCells makes Cell return the anchor cell's value for any cell
+// that falls inside a merged region, instead of the empty string the XML
+// gives every non-anchor cell of the region.
+func ExpandMergedCells() CellOption {
+	return func(o *cellOpts) { o.expandMerged = true }
 }
 
 // Cell returns the contents of cell at column, row, where 1,1 is the top left corner. The return value is always a string.
 // The user is in charge to convert this value to a number, if necessary. Formulae are not returned.
-func (ws *Worksheet) Cell(column, row int) string {
+// By default, a cell inside a merged region other than its anchor reads as
+// empty, matching the underlying XML; pass ExpandMergedCells to have it
+// return the anchor's value instead.
+func (ws *Worksheet) Cell(column, row int, opts ...CellOption) string {
+	var o cellOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	value := ws.rawCellValue(column, row)
+	if value != "" || !o.expandMerged {
+		return value
+	}
+	if anchorCol, anchorRow, ok := ws.mergeAnchor(column, row); ok {
+		return ws.rawCellValue(anchorCol, anchorRow)
+	}
+	return value
+}
+
+func (ws *Worksheet) rawCellValue(column, row int) string {
 	xrow := ws.rows[row]
 	if xrow == nil {
 		return ""
@@ -171,6 +335,167 @@ func (ws *Worksheet) Cell(column, row int) string {
 	return xrow.Cells[column].Value
 }
 
+// mergeAnchor reports the top-left cell of the merged region containing
+// column, row, if any.
+func (ws *Worksheet) mergeAnchor(column, row int) (col, anchorRow int, ok bool) {
+	for _, r := range ws.mergedCells {
+		if column >= r.MinCol && column <= r.MaxCol && row >= r.MinRow && row <= r.MaxRow {
+			return r.MinCol, r.MinRow, true
+		}
+	}
+	return 0, 0, false
+}
+
+// CellRange is an inclusive rectangular range of cells, such as a merged
+// region or a range reference like "A1:C10".
+type CellRange struct {
+	MinCol, MinRow, MaxCol, MaxRow int
+}
+
+// MergedCells returns the worksheet's merged cell regions, parsed from its
+// <mergeCells> element.
+func (ws *Worksheet) MergedCells() []CellRange {
+	return ws.mergedCells
+}
+
+// Formula returns the formula text of a cell, or "" if it has none. For a
+// cell that is part of a shared formula group, the master cell's original
+// formula text is returned verbatim for every cell in the group: this
+// reader does not evaluate formulas, so no relative-reference translation
+// is performed, only the group's cached <v> values differ per cell.
+func (ws *Worksheet) Formula(column, row int) string {
+	c := ws.cellAt(column, row)
+	if c == nil {
+		return ""
+	}
+	return c.Formula
+}
+
+// CellError represents a formula error value stored in a cell, such as
+// #DIV/0! or #N/A.
+type CellError struct {
+	Value string
+}
+
+func (e *CellError) Error() string {
+	return e.Value
+}
+
+func (ws *Worksheet) cellAt(column, row int) *cell {
+	xrow := ws.rows[row]
+	if xrow == nil {
+		return nil
+	}
+	return xrow.Cells[column]
+}
+
+// CellValue returns the cell's value as a string with its number format
+// applied, so a date serial such as 44197 formatted as "yyyy-mm-dd" is
+// rendered as "2021-01-01" and
+// 0.125 formatted as "0.00%" is rendered as "12.50%". Error cells
+// (#DIV/0! etc.) are reported via a *CellError.
+func (ws *Worksheet) CellValue(column, row int) (string, error) {
+	c := ws.cellAt(column, row)
+	if c == nil {
+		return "", nil
+	}
+	switch c.Type {
+	case "e":
+		return "", &CellError{Value: c.Value}
+	case "s", "str", "inlineStr":
+		return c.Value, nil
+	case "b":
+		if c.Value == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	}
+
+	code := ws.spreadsheet.numFmtCode(c.NumFmt)
+	f, err := strconv.ParseFloat(c.Value, 64)
+	if code == "General" || code == "@" || err != nil {
+		return c.Value, nil
+	}
+	if isDateFormatCode(code) {
+		return formatDate(excelSerialToTime(f, ws.spreadsheet.date1904), code), nil
+	}
+	return formatNumberCode(f, code), nil
+}
+
+// CellInt returns the cell's numeric value truncated to an int, ignoring
+// any number format.
+func (ws *Worksheet) CellInt(column, row int) (int, error) {
+	f, err := ws.CellFloat(column, row)
+	if err != nil {
+		return 0, err
+	}
+	return int(f), nil
+}
+
+// CellFloat returns the cell's raw numeric value, ignoring any number format.
+func (ws *Worksheet) CellFloat(column, row int) (float64, error) {
+	c := ws.cellAt(column, row)
+	if c == nil {
+		return 0, nil
+	}
+	if c.Type == "e" {
+		return 0, &CellError{Value: c.Value}
+	}
+	return strconv.ParseFloat(c.Value, 64)
+}
+
+// CellTime interprets the cell's numeric value as an Excel date/time serial
+// and converts it to a time.Time, honoring the workbook's 1900/1904 date
+// system.
+func (ws *Worksheet) CellTime(column, row int) (time.Time, error) {
+	f, err := ws.CellFloat(column, row)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return excelSerialToTime(f, ws.spreadsheet.date1904), nil
+}
+
+// CellBool returns the cell's boolean value. It returns an error if the
+// cell holds a value that is not a boolean; an absent cell reads as false
+// with no error.
+func (ws *Worksheet) CellBool(column, row int) (bool, error) {
+	c := ws.cellAt(column, row)
+	if c == nil {
+		return false, nil
+	}
+	if c.Type == "e" {
+		return false, &CellError{Value: c.Value}
+	}
+	if c.Type != "b" {
+		return false, fmt.Errorf("cell %d,%d is not a boolean", column, row)
+	}
+	return c.Value == "1", nil
+}
+
+// RichRun is a contiguous run of text sharing the same formatting within a
+// cell's rich text value, taken from a shared string's or inline string's
+// <r> run and its <rPr>.
+type RichRun struct {
+	Text   string
+	Bold   bool
+	Italic bool
+	Font   string
+	Color  string // ARGB/RGB hex such as "FFFF0000"; empty if not set.
+}
+
+// CellRichText returns the formatted runs of a shared-string ("s") or
+// inline-string ("inlineStr") cell. It returns nil for cells of any other
+// type, for empty strings, and for string cells set in memory with SetCell;
+// a non-empty string cell read from a file with no explicit <r> runs yields
+// a single unformatted run.
+func (ws *Worksheet) CellRichText(column, row int) []RichRun {
+	c := ws.cellAt(column, row)
+	if c == nil {
+		return nil
+	}
+	return c.richRuns
+}
+
 func (s *Spreadsheet) readWorksheet(data []byte) (*Worksheet, error) {
 	wsXlsx := &xlsxWorksheet{}
 	err := xml.Unmarshal(data, wsXlsx)
@@ -179,10 +504,24 @@ func (s *Spreadsheet) readWorksheet(data []byte) (*Worksheet, error) {
 	}
 	ws := &Worksheet{}
 	ws.rows = make(map[int]*row)
-	tmp := strings.Split(wsXlsx.Dimension.Ref, ":")
-	ws.MinColumn, ws.MinRow = stringToPosition(tmp[0])
-	ws.MaxColumn, ws.MaxRow = stringToPosition(tmp[1])
+	haveDimension := wsXlsx.Dimension.Ref != ""
+	if haveDimension {
+		dim, err := ParseRange(wsXlsx.Dimension.Ref)
+		if err != nil {
+			return nil, err
+		}
+		ws.MinColumn, ws.MinRow, ws.MaxColumn, ws.MaxRow = dim.MinCol, dim.MinRow, dim.MaxCol, dim.MaxRow
+	}
+
+	for _, mc := range wsXlsx.MergeCells.Cell {
+		r, err := ParseRange(mc.Ref)
+		if err != nil {
+			continue
+		}
+		ws.mergedCells = append(ws.mergedCells, r)
+	}
 
+	sharedFormulas := make(map[string]string)
 	var currentRow *row
 
 	for xrow := 0; xrow < len(wsXlsx.Row); xrow++ {
@@ -194,46 +533,275 @@ func (s *Spreadsheet) readWorksheet(data []byte) (*Worksheet, error) {
 		ws.rows[thisrow.Rownumber] = currentRow
 
 		for col := 0; col < len(thisrow.Cols); col++ {
-			var cellnumber rune
 			thiscol := thisrow.Cols[col]
-			for _, v := range thiscol.R {
-				if v >= 'A' && v <= 'Z' {
-					cellnumber = cellnumber*26 + v - 'A' + 1
-				}
+			colName, _, err := SplitCellName(thiscol.R)
+			if err != nil {
+				return nil, err
+			}
+			cellnumber, err := ColumnNameToNumber(colName)
+			if err != nil {
+				return nil, err
 			}
 			currentCell := &cell{}
+			currentCell.NumFmt = -1
+			if idx, err := strconv.Atoi(thiscol.S); err == nil {
+				currentCell.NumFmt = idx
+			}
 
-			currentRow.Cells[int(cellnumber)] = currentCell
+			currentRow.Cells[cellnumber] = currentCell
 
-			if thiscol.T == "s" {
+			switch thiscol.T {
+			case "s":
 				v, err := strconv.Atoi(thiscol.V)
 				if err != nil {
 					return nil, err
 				}
-				currentCell.Value = s.sharedStrings[v]
+				if v >= 0 && v < len(s.sharedStrings) {
+					currentCell.Value = s.sharedStrings[v]
+				}
 				currentCell.Type = "s"
-			} else if thiscol.T == "" {
-				currentCell.Type = "v"
+				if v >= 0 && v < len(s.sharedRuns) {
+					currentCell.richRuns = s.sharedRuns[v]
+				}
+			case "inlineStr":
+				currentCell.Type = "inlineStr"
+				currentCell.Value = richText(thiscol.Is)
+				currentCell.richRuns = richRuns(thiscol.Is)
+			case "":
+				currentCell.Type = "n"
+				currentCell.Value = thiscol.V
+			default:
+				// "n", "b", "str", "e", "d" all carry their value verbatim in <v>.
+				currentCell.Type = thiscol.T
 				currentCell.Value = thiscol.V
 			}
 
+			if thiscol.F.T == "shared" {
+				if thiscol.F.Expr != "" {
+					sharedFormulas[thiscol.F.Si] = thiscol.F.Expr
+					currentCell.Formula = thiscol.F.Expr
+				} else {
+					currentCell.Formula = sharedFormulas[thiscol.F.Si]
+				}
+			} else if thiscol.F.Expr != "" {
+				currentCell.Formula = thiscol.F.Expr
+			}
 		}
 	}
+
+	if !haveDimension {
+		for rowNum, r := range ws.rows {
+			if ws.MinRow == 0 || rowNum < ws.MinRow {
+				ws.MinRow = rowNum
+			}
+			if rowNum > ws.MaxRow {
+				ws.MaxRow = rowNum
+			}
+			for colNum := range r.Cells {
+				if ws.MinColumn == 0 || colNum < ws.MinColumn {
+					ws.MinColumn = colNum
+				}
+				if colNum > ws.MaxColumn {
+					ws.MaxColumn = colNum
+				}
+			}
+		}
+	}
+
 	return ws, nil
 }
 
-// GetWorksheet returns the worksheet with the given number, starting at 0.
-func (s *Spreadsheet) GetWorksheet(number int) (*Worksheet, error) {
+// Worksheet returns the worksheet with the given number, starting at 0,
+// without parsing its contents: only metadata (Name and its location within
+// the archive) is populated. Use this together with Rows to stream a large
+// sheet's rows without ever holding the whole sheet in memory; use
+// GetWorksheet instead for random-access Cell-style reads.
+func (s *Spreadsheet) Worksheet(number int) (*Worksheet, error) {
 	if number >= len(s.worksheets) || number < 0 {
 		return nil, errors.New("index out of range")
 	}
-	rid := s.worksheets[number].rid
-	filename := "xl/" + s.relationships[rid].Target
-	ws, err := s.readWorksheet(s.uncompressedFiles[filename])
-	ws.filename = filename
-	ws.Name = s.worksheets[number].Name
+	return s.worksheets[number], nil
+}
+
+// GetWorksheet returns the worksheet with the given number, starting at 0,
+// fully parsed into memory.
+func (s *Spreadsheet) GetWorksheet(number int) (*Worksheet, error) {
+	meta, err := s.Worksheet(number)
+	if err != nil {
+		return nil, err
+	}
+	if meta.rows != nil {
+		// Created in memory via NewWorksheet, with no backing zip entry to
+		// parse: return it as-is.
+		return meta, nil
+	}
+	data, err := s.readZipFile(meta.filename)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := s.readWorksheet(data)
 	if err != nil {
 		return nil, err
 	}
+	ws.filename = meta.filename
+	ws.Name = meta.Name
+	ws.spreadsheet = s
 	return ws, nil
 }
+
+// RowIterator streams the rows of a worksheet one at a time, parsing the
+// underlying XML with xml.Decoder.Token instead of unmarshaling the whole
+// sheet. This keeps peak memory bounded to a single row, which matters for
+// worksheets with hundreds of thousands of rows. Obtain one with Worksheet.Rows.
+type RowIterator struct {
+	dec           *xml.Decoder
+	rc            io.ReadCloser
+	sharedStrings []string
+	row           []string
+	err           error
+	closed        bool
+}
+
+// Rows returns a streaming row iterator over ws. It reads the worksheet's
+// XML directly from the zip archive, so it works whether ws came from
+// Spreadsheet.Worksheet (never parsed) or GetWorksheet (already parsed),
+// and independent of any rows already loaded on ws.
+func (ws *Worksheet) Rows() (*RowIterator, error) {
+	f, ok := ws.spreadsheet.zipFiles[ws.filename]
+	if !ok {
+		return nil, fmt.Errorf("worksheet file %q not found in archive", ws.filename)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	return &RowIterator{
+		dec:           xml.NewDecoder(rc),
+		rc:            rc,
+		sharedStrings: ws.spreadsheet.sharedStrings,
+	}, nil
+}
+
+// Next advances the iterator to the next row and reports whether one was
+// found. Callers should check Err after Next returns false to distinguish
+// "no more rows" from a parse error.
+func (it *RowIterator) Next() bool {
+	if it.closed || it.err != nil {
+		return false
+	}
+	for {
+		tok, err := it.dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				it.err = nil
+			} else {
+				it.err = err
+			}
+			it.close()
+			return false
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		row, err := it.readRow()
+		if err != nil {
+			it.err = err
+			it.close()
+			return false
+		}
+		it.row = row
+		return true
+	}
+}
+
+// readRow consumes tokens up to and including the row's closing element,
+// returning the cell values in column order (1-based columns become
+// 0-based slice indices, with gaps for skipped columns filled as "").
+func (it *RowIterator) readRow() ([]string, error) {
+	var (
+		cells    []string
+		curCol   int
+		curType  string
+		curValue strings.Builder
+		inValue  bool
+	)
+	for {
+		tok, err := it.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "c":
+				curCol = 0
+				curType = ""
+				curValue.Reset()
+				for _, a := range t.Attr {
+					switch a.Name.Local {
+					case "r":
+						if colName, _, err := SplitCellName(a.Value); err == nil {
+							curCol, _ = ColumnNameToNumber(colName)
+						}
+					case "t":
+						curType = a.Value
+					}
+				}
+			case "v", "t":
+				// For inlineStr cells, "t" may appear more than once (one per
+				// rich text run); the values are concatenated, not reset.
+				inValue = true
+			}
+		case xml.CharData:
+			if inValue {
+				curValue.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "v", "t":
+				inValue = false
+			case "c":
+				for len(cells) < curCol {
+					cells = append(cells, "")
+				}
+				value := curValue.String()
+				if curType == "s" {
+					idx, err := strconv.Atoi(value)
+					if err == nil && idx >= 0 && idx < len(it.sharedStrings) {
+						value = it.sharedStrings[idx]
+					}
+				}
+				if curCol > 0 {
+					cells[curCol-1] = value
+				}
+			case "row":
+				return cells, nil
+			}
+		}
+	}
+}
+
+// Row returns the cell values of the row last advanced to by Next.
+func (it *RowIterator) Row() []string {
+	return it.row
+}
+
+// Err returns the first error encountered while iterating, if any.
+func (it *RowIterator) Err() error {
+	return it.err
+}
+
+// Close releases the zip entry backing the iterator. It is safe to call
+// after iteration has already finished naturally.
+func (it *RowIterator) Close() error {
+	return it.close()
+}
+
+func (it *RowIterator) close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	return it.rc.Close()
+}